@@ -0,0 +1,153 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DataVolumeContentType represents the contents of a DataVolume
+type DataVolumeContentType string
+
+const (
+	// DataVolumeKubeVirt is the content-type of the imported file, defaults to kubevirt content-type if empty
+	DataVolumeKubeVirt DataVolumeContentType = "kubevirt"
+	// DataVolumeArchive is the content-type of the imported file, tar archive
+	DataVolumeArchive DataVolumeContentType = "archive"
+)
+
+// DataVolumePhase is the current phase of the DataVolume
+type DataVolumePhase string
+
+// DataVolume is an abstraction on top of PersistentVolumeClaims to allow easy population of a PVC for KubeVirt
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataVolume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DataVolumeSpec   `json:"spec"`
+	Status DataVolumeStatus `json:"status,omitempty"`
+}
+
+// DataVolumeList provides the needed parameters to do request a list of DataVolumes from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataVolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []DataVolume `json:"items"`
+}
+
+// DataVolumeSpec defines the DataVolume type
+type DataVolumeSpec struct {
+	// Source is the src of the data for the requested DataVolume
+	Source DataVolumeSource `json:"source"`
+	// PVC is the PVC specification to use for this data volume
+	PVC *corev1.PersistentVolumeClaimSpec `json:"pvc,omitempty"`
+	// DataVolumeContentType options: "kubevirt", "archive"
+	ContentType DataVolumeContentType `json:"contentType,omitempty"`
+}
+
+// DataVolumeSource represents the source for our DataVolume, this can be HTTP, Imageio, S3, Registry, Upload, Blank, an existing PVC or an existing VolumeSnapshot
+type DataVolumeSource struct {
+	HTTP     *DataVolumeSourceHTTP     `json:"http,omitempty"`
+	S3       *DataVolumeSourceS3       `json:"s3,omitempty"`
+	Registry *DataVolumeSourceRegistry `json:"registry,omitempty"`
+	PVC      *DataVolumeSourcePVC      `json:"pvc,omitempty"`
+	Upload   *DataVolumeSourceUpload   `json:"upload,omitempty"`
+	Blank    *DataVolumeBlankImage     `json:"blank,omitempty"`
+	Imageio  *DataVolumeSourceImageio  `json:"imageio,omitempty"`
+	// VolumeSnapshot provides the parameters to create a Data Volume from an existing VolumeSnapshot
+	VolumeSnapshot *DataVolumeSourceVolumeSnapshot `json:"snapshot,omitempty"`
+}
+
+// DataVolumeBlankImage provides the parameters to create a new raw blank image for the PVC
+type DataVolumeBlankImage struct{}
+
+// DataVolumeSourcePVC provides the parameters to create a Data Volume from an existing PVC
+type DataVolumeSourcePVC struct {
+	// Namespace is the namespace of the source PVC
+	Namespace string `json:"namespace"`
+	// Name is the name of the source PVC
+	Name string `json:"name"`
+}
+
+// DataVolumeSourceVolumeSnapshot provides the parameters to create a Data Volume from an existing VolumeSnapshot
+type DataVolumeSourceVolumeSnapshot struct {
+	// Namespace is the namespace of the source VolumeSnapshot
+	Namespace string `json:"namespace"`
+	// Name is the name of the source VolumeSnapshot
+	Name string `json:"name"`
+}
+
+// DataVolumeSourceHTTP can be either an http or https endpoint, with an optional basic auth user name and password, and an optional configmap containing additional CAs
+type DataVolumeSourceHTTP struct {
+	// URL is the URL of the http(s) endpoint
+	URL string `json:"url"`
+	// SecretRef A Secret reference, the secret should contain accessKeyId (user name) base64 encoded, and secretKey (password) also base64 encoded
+	SecretRef string `json:"secretRef,omitempty"`
+	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+}
+
+// DataVolumeSourceS3 provides the parameters to create a Data Volume from an S3 source
+type DataVolumeSourceS3 struct {
+	// URL is the url of the S3 source
+	URL string `json:"url"`
+	// SecretRef provides the secret reference needed to access the S3 source
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// DataVolumeSourceRegistry provides the parameters to create a Data Volume from a registry source
+type DataVolumeSourceRegistry struct {
+	// URL is the url of the Docker registry source
+	URL string `json:"url"`
+	// SecretRef provides the secret reference needed to access the Registry source
+	SecretRef string `json:"secretRef,omitempty"`
+	// CertConfigMap provides a reference to the Registry certs
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+}
+
+// DataVolumeSourceUpload provides the parameters to create a Data Volume by uploading the source
+type DataVolumeSourceUpload struct{}
+
+// DataVolumeSourceImageio provides the parameters to create a Data Volume from an imageio source
+type DataVolumeSourceImageio struct {
+	// URL is the URL of the ovirt-engine
+	URL string `json:"url"`
+	// DiskID provides id of a disk to be imported
+	DiskID string `json:"diskId"`
+	// SecretRef provides the secret reference needed to access the ovirt-engine
+	SecretRef string `json:"secretRef,omitempty"`
+	// CertConfigMap provides a reference to the CA cert
+	CertConfigMap string `json:"certConfigMap,omitempty"`
+}
+
+// DataVolumeStatus contains the current status of the DataVolume
+type DataVolumeStatus struct {
+	// Phase is the current phase of the data volume
+	Phase DataVolumePhase `json:"phase,omitempty"`
+	// Progress is the current progress of the data volume transfer operation. Value between 0 and 100 inclusive, N/A if not available
+	Progress string `json:"progress,omitempty"`
+	// RestartCount is the number of times the pod populating the DataVolume has restarted
+	RestartCount int32 `json:"restartCount,omitempty"`
+}
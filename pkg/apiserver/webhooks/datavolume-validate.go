@@ -23,12 +23,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"os"
 	"reflect"
 
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/clientset/versioned"
 	"k8s.io/api/admission/v1beta1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog"
@@ -37,8 +41,23 @@ import (
 	"kubevirt.io/containerized-data-importer/pkg/controller"
 )
 
+// cloneSourceLabelsConfigMapName is the well-known ConfigMap, in the CDI install
+// namespace, whose data holds the label selector that source PVCs referenced by
+// Source.PVC must satisfy. Absence of the ConfigMap (or of this key) means no
+// restriction is enforced, preserving today's behavior.
+const cloneSourceLabelsConfigMapName = "cdi-clone-source-allow-labels"
+const cloneSourceLabelsConfigMapKey = "allowCloneFromLabels"
+
 type dataVolumeValidatingWebhook struct {
-	client kubernetes.Interface
+	client         kubernetes.Interface
+	snapshotClient snapshotv1.Interface
+}
+
+func getCDINamespace() string {
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		return ns
+	}
+	return "cdi"
 }
 
 func validateSourceURL(sourceURL string) string {
@@ -182,6 +201,12 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *v1beta1.A
 					})
 					return causes
 				}
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("Unable to retrieve source PVC %s/%s: %v", spec.Source.PVC.Namespace, spec.Source.PVC.Name, err),
+					Field:   field.Child("source", "PVC").String(),
+				})
+				return causes
 			}
 			err = controller.ValidateCanCloneSourceAndTargetSpec(&sourcePVC.Spec, spec.PVC)
 			if err != nil {
@@ -192,6 +217,40 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *v1beta1.A
 				})
 				return causes
 			}
+
+			if cause := wh.validateCloneAuthorization(request, spec.Source.PVC.Namespace, field.Child("source", "PVC", "namespace")); cause != nil {
+				causes = append(causes, *cause)
+				return causes
+			}
+
+			if cause := wh.validateCloneSourceLabels(sourcePVC.Labels, field); cause != nil {
+				causes = append(causes, *cause)
+				return causes
+			}
+		}
+	}
+
+	if spec.Source.VolumeSnapshot != nil {
+		if spec.Source.VolumeSnapshot.Namespace == "" || spec.Source.VolumeSnapshot.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s source VolumeSnapshot is not valid", field.Child("source", "VolumeSnapshot").String()),
+				Field:   field.Child("source", "VolumeSnapshot").String(),
+			})
+			return causes
+		}
+
+		if request.Operation == v1beta1.Create {
+			if cause := wh.validateCloneAuthorization(request, spec.Source.VolumeSnapshot.Namespace, field.Child("source", "VolumeSnapshot", "namespace")); cause != nil {
+				causes = append(causes, *cause)
+				return causes
+			}
+
+			cause := wh.validateVolumeSnapshotSource(spec, field)
+			if cause != nil {
+				causes = append(causes, *cause)
+				return causes
+			}
 		}
 	}
 
@@ -203,6 +262,12 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *v1beta1.A
 		})
 		return causes
 	}
+
+	if cause := validateVolumeModeAndContentType(spec, field); cause != nil {
+		causes = append(causes, *cause)
+		return causes
+	}
+
 	if pvcSize, ok := spec.PVC.Resources.Requests["storage"]; ok {
 		if pvcSize.IsZero() || pvcSize.Value() < 0 {
 			causes = append(causes, metav1.StatusCause{
@@ -239,9 +304,236 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *v1beta1.A
 		})
 		return causes
 	}
+
 	return causes
 }
 
+// validateVolumeModeAndContentType rejects combinations of spec.PVC.volumeMode and
+// spec.contentType/spec.source that the importer pod cannot actually honor: a Block
+// volume can only ever hold a raw kubevirt disk image, never an archive or a blank
+// filesystem of zero size, and the Registry (container disk) import path writes a
+// filesystem image so it cannot target a Block PVC either.
+func validateVolumeModeAndContentType(spec *cdicorev1alpha1.DataVolumeSpec, field *k8sfield.Path) *metav1.StatusCause {
+	if spec.PVC.VolumeMode == nil || *spec.PVC.VolumeMode != v1.PersistentVolumeBlock {
+		return nil
+	}
+
+	blockSourceAllowed := spec.Source.HTTP != nil || spec.Source.S3 != nil ||
+		spec.Source.PVC != nil || spec.Source.Upload != nil || spec.Source.Imageio != nil || spec.Source.Blank != nil
+	if !blockSourceAllowed {
+		return &metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "volumeMode Block is only supported for HTTP, S3, PVC, Upload, Imageio and Blank sources",
+			Field:   field.Child("PVC", "volumeMode").String(),
+		}
+	}
+
+	if spec.ContentType != "" && string(spec.ContentType) != string(cdicorev1alpha1.DataVolumeKubeVirt) {
+		return &metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("contentType %s not supported for volumeMode Block", spec.ContentType),
+			Field:   field.Child("contentType").String(),
+		}
+	}
+
+	if spec.Source.Registry != nil {
+		return &metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "Registry source does not support volumeMode Block",
+			Field:   field.Child("PVC", "volumeMode").String(),
+		}
+	}
+
+	if spec.Source.Blank != nil {
+		if pvcSize, ok := spec.PVC.Resources.Requests["storage"]; ok && pvcSize.IsZero() {
+			return &metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "Blank source with volumeMode Block requires a non-zero size",
+				Field:   field.Child("PVC", "volumeMode").String(),
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCloneAuthorization performs a SubjectAccessReview on behalf of the user
+// that submitted the DataVolume, confirming they are allowed to clone from a PVC in
+// a different namespace than the DataVolume's own. Cloning within the same
+// namespace relies on the DataVolume create permission the user already has.
+func (wh *dataVolumeValidatingWebhook) validateCloneAuthorization(request *v1beta1.AdmissionRequest, sourceNamespace string, namespaceField *k8sfield.Path) *metav1.StatusCause {
+	if sourceNamespace == "" || sourceNamespace == request.Namespace {
+		return nil
+	}
+
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   request.UserInfo.Username,
+			UID:    request.UserInfo.UID,
+			Groups: request.UserInfo.Groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   sourceNamespace,
+				Verb:        "create",
+				Group:       cdicorev1alpha1.SchemeGroupVersion.Group,
+				Resource:    "datavolumes",
+				Subresource: "source",
+			},
+		},
+	}
+
+	response, err := wh.client.AuthorizationV1().SubjectAccessReviews().Create(sar)
+	if err != nil {
+		return &metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Unable to verify permission to clone from namespace %s: %v", sourceNamespace, err),
+			Field:   namespaceField.String(),
+		}
+	}
+
+	if !response.Status.Allowed {
+		return &metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("User %s has insufficient permissions in clone source namespace %s", request.UserInfo.Username, sourceNamespace),
+			Field:   namespaceField.String(),
+		}
+	}
+
+	return nil
+}
+
+// validateCloneSourceLabels enforces the operator-configured allowlist of labels a
+// source PVC must carry to be clonable, loaded from the cloneSourceLabelsConfigMapName
+// ConfigMap in the CDI namespace. No ConfigMap (or no selector configured) means no
+// restriction, so existing clones keep working unless an operator opts in.
+func (wh *dataVolumeValidatingWebhook) validateCloneSourceLabels(sourcePVCLabels map[string]string, field *k8sfield.Path) *metav1.StatusCause {
+	cm, err := wh.client.CoreV1().ConfigMaps(getCDINamespace()).Get(cloneSourceLabelsConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return &metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Unable to read clone source label allowlist: %v", err),
+			Field:   field.Child("source", "PVC").String(),
+		}
+	}
+
+	selectorString, ok := cm.Data[cloneSourceLabelsConfigMapKey]
+	if !ok || selectorString == "" {
+		return nil
+	}
+
+	selector, err := labels.Parse(selectorString)
+	if err != nil {
+		return &metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Invalid clone source label allowlist %q: %v", selectorString, err),
+			Field:   field.Child("source", "PVC").String(),
+		}
+	}
+
+	if !selector.Matches(labels.Set(sourcePVCLabels)) {
+		return &metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Source PVC labels do not satisfy the configured clone source selector %q", selector.String()),
+			Field:   field.Child("source", "PVC").String(),
+		}
+	}
+
+	return nil
+}
+
+// validateVolumeSnapshotSource confirms the referenced VolumeSnapshot exists, is
+// ready to restore from, and is compatible with the requested target PVC before the
+// importer is allowed to provision the PVC straight from the snapshot's dataSource.
+func (wh *dataVolumeValidatingWebhook) validateVolumeSnapshotSource(spec *cdicorev1alpha1.DataVolumeSpec, field *k8sfield.Path) *metav1.StatusCause {
+	snapshotSource := spec.Source.VolumeSnapshot
+	snapshot, err := wh.snapshotClient.SnapshotV1().VolumeSnapshots(snapshotSource.Namespace).Get(snapshotSource.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return &metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotFound,
+				Message: fmt.Sprintf("Source VolumeSnapshot %s/%s doesn't exist", snapshotSource.Namespace, snapshotSource.Name),
+				Field:   field.Child("source", "VolumeSnapshot").String(),
+			}
+		}
+		return &metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Unable to retrieve source VolumeSnapshot %s/%s: %v", snapshotSource.Namespace, snapshotSource.Name, err),
+			Field:   field.Child("source", "VolumeSnapshot").String(),
+		}
+	}
+
+	if snapshot.Status == nil || snapshot.Status.ReadyToUse == nil || !*snapshot.Status.ReadyToUse {
+		return &metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("Source VolumeSnapshot %s/%s is not ready to use", snapshotSource.Namespace, snapshotSource.Name),
+			Field:   field.Child("source", "VolumeSnapshot").String(),
+		}
+	}
+
+	if spec.PVC == nil {
+		return nil
+	}
+
+	if pvcSize, ok := spec.PVC.Resources.Requests["storage"]; ok && snapshot.Status.RestoreSize != nil {
+		if snapshot.Status.RestoreSize.Cmp(pvcSize) > 0 {
+			return &metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Target PVC size %s is smaller than snapshot restoreSize %s", pvcSize.String(), snapshot.Status.RestoreSize.String()),
+				Field:   field.Child("PVC", "resources", "requests", "size").String(),
+			}
+		}
+	}
+
+	// Both the target's storage class and the snapshot's VolumeSnapshotClass are
+	// commonly left unset in favor of a cluster default, so this compatibility check
+	// is intentionally best-effort: it only runs when both are explicitly named. Once
+	// it does run, a lookup error fails closed instead of being swallowed -- the
+	// caller named a class and we couldn't confirm it's compatible, which is not the
+	// same as there being nothing to check.
+	if spec.PVC.StorageClassName != nil && snapshot.Spec.VolumeSnapshotClassName != nil {
+		targetProvisioner, err := wh.provisionerForStorageClass(*spec.PVC.StorageClassName)
+		if err != nil {
+			return &metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Unable to verify target StorageClass %s: %v", *spec.PVC.StorageClassName, err),
+				Field:   field.Child("PVC", "storageClassName").String(),
+			}
+		}
+
+		snapshotClass, err := wh.snapshotClient.SnapshotV1().VolumeSnapshotClasses().Get(*snapshot.Spec.VolumeSnapshotClassName, metav1.GetOptions{})
+		if err != nil {
+			return &metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Unable to verify source VolumeSnapshotClass %s: %v", *snapshot.Spec.VolumeSnapshotClassName, err),
+				Field:   field.Child("source", "VolumeSnapshot").String(),
+			}
+		}
+
+		if targetProvisioner != "" && snapshotClass.Driver != targetProvisioner {
+			return &metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Target PVC storage class %s (provisioner %s) is not compatible with snapshot driver %s", *spec.PVC.StorageClassName, targetProvisioner, snapshotClass.Driver),
+				Field:   field.Child("PVC", "storageClassName").String(),
+			}
+		}
+	}
+
+	return nil
+}
+
+// provisionerForStorageClass looks up the CSI provisioner backing a StorageClass, so
+// VolumeSnapshot restores can be checked for compatibility against the target PVC by
+// provisioner rather than by storage class name, which may legitimately differ.
+func (wh *dataVolumeValidatingWebhook) provisionerForStorageClass(storageClassName string) (string, error) {
+	sc, err := wh.client.StorageV1().StorageClasses().Get(storageClassName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return sc.Provisioner, nil
+}
+
 func (wh *dataVolumeValidatingWebhook) Admit(ar v1beta1.AdmissionReview) *v1beta1.AdmissionResponse {
 	if err := validateDataVolumeResource(ar); err != nil {
 		return toAdmissionResponseError(err)
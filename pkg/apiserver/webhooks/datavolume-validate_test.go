@@ -0,0 +1,233 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package webhooks
+
+import (
+	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	snapshotfake "github.com/kubernetes-csi/external-snapshotter/client/v4/clientset/versioned/fake"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/api/admission/v1beta1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	cdicorev1alpha1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+)
+
+func blockMode() *v1.PersistentVolumeMode {
+	mode := v1.PersistentVolumeBlock
+	return &mode
+}
+
+func newBlockPVCSpec(source cdicorev1alpha1.DataVolumeSource, size string) *cdicorev1alpha1.DataVolumeSpec {
+	return &cdicorev1alpha1.DataVolumeSpec{
+		Source: source,
+		PVC: &v1.PersistentVolumeClaimSpec{
+			VolumeMode:  blockMode(),
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceStorage: resource.MustParse(size),
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("validateVolumeModeAndContentType", func() {
+	table.DescribeTable("rejects incompatible Block combinations", func(spec *cdicorev1alpha1.DataVolumeSpec, expectedField string) {
+		cause := validateVolumeModeAndContentType(spec, k8sfield.NewPath("spec"))
+		Expect(cause).ToNot(BeNil())
+		Expect(cause.Field).To(Equal(expectedField))
+	},
+		table.Entry("Block + archive contentType",
+			func() *cdicorev1alpha1.DataVolumeSpec {
+				spec := newBlockPVCSpec(cdicorev1alpha1.DataVolumeSource{Blank: &cdicorev1alpha1.DataVolumeBlankImage{}}, "1Gi")
+				spec.ContentType = cdicorev1alpha1.DataVolumeArchive
+				return spec
+			}(),
+			"spec.contentType"),
+		table.Entry("Block + Registry source",
+			newBlockPVCSpec(cdicorev1alpha1.DataVolumeSource{Registry: &cdicorev1alpha1.DataVolumeSourceRegistry{URL: "docker://x"}}, "1Gi"),
+			"spec.PVC.volumeMode"),
+		table.Entry("Block + zero size Blank source",
+			newBlockPVCSpec(cdicorev1alpha1.DataVolumeSource{Blank: &cdicorev1alpha1.DataVolumeBlankImage{}}, "0"),
+			"spec.PVC.volumeMode"),
+		table.Entry("Block + unsupported source (VolumeSnapshot)",
+			newBlockPVCSpec(cdicorev1alpha1.DataVolumeSource{VolumeSnapshot: &cdicorev1alpha1.DataVolumeSourceVolumeSnapshot{Namespace: "ns", Name: "snap"}}, "1Gi"),
+			"spec.PVC.volumeMode"),
+	)
+
+	It("allows Block with a supported source and sufficient size", func() {
+		spec := newBlockPVCSpec(cdicorev1alpha1.DataVolumeSource{Blank: &cdicorev1alpha1.DataVolumeBlankImage{}}, "1Gi")
+		cause := validateVolumeModeAndContentType(spec, k8sfield.NewPath("spec"))
+		Expect(cause).To(BeNil())
+	})
+})
+
+var _ = Describe("validateCloneAuthorization", func() {
+	It("rejects when the SubjectAccessReview is denied", func() {
+		client := k8sfake.NewSimpleClientset()
+		client.PrependReactor("create", "subjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, &authorizationv1.SubjectAccessReview{
+				Status: authorizationv1.SubjectAccessReviewStatus{Allowed: false},
+			}, nil
+		})
+		wh := &dataVolumeValidatingWebhook{client: client}
+
+		request := &v1beta1.AdmissionRequest{Namespace: "target-ns"}
+		cause := wh.validateCloneAuthorization(request, "source-ns", k8sfield.NewPath("spec"))
+		Expect(cause).ToNot(BeNil())
+		Expect(cause.Type).To(Equal(metav1.CauseTypeFieldValueInvalid))
+	})
+
+	It("allows cloning within the same namespace without a review", func() {
+		wh := &dataVolumeValidatingWebhook{client: k8sfake.NewSimpleClientset()}
+		request := &v1beta1.AdmissionRequest{Namespace: "same-ns"}
+		cause := wh.validateCloneAuthorization(request, "same-ns", k8sfield.NewPath("spec"))
+		Expect(cause).To(BeNil())
+	})
+})
+
+var _ = Describe("validateCloneSourceLabels", func() {
+	It("rejects source PVC labels that do not satisfy the configured allowlist", func() {
+		client := k8sfake.NewSimpleClientset(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: cloneSourceLabelsConfigMapName, Namespace: getCDINamespace()},
+			Data:       map[string]string{cloneSourceLabelsConfigMapKey: "cloneable=true"},
+		})
+		wh := &dataVolumeValidatingWebhook{client: client}
+
+		cause := wh.validateCloneSourceLabels(map[string]string{"cloneable": "false"}, k8sfield.NewPath("spec"))
+		Expect(cause).ToNot(BeNil())
+	})
+
+	It("allows when no allowlist ConfigMap exists", func() {
+		wh := &dataVolumeValidatingWebhook{client: k8sfake.NewSimpleClientset()}
+		cause := wh.validateCloneSourceLabels(map[string]string{"anything": "goes"}, k8sfield.NewPath("spec"))
+		Expect(cause).To(BeNil())
+	})
+})
+
+var _ = Describe("validateVolumeSnapshotSource", func() {
+	It("rejects a snapshot that is not ready to use", func() {
+		notReady := false
+		snapshotClient := snapshotfake.NewSimpleClientset(&volumesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "snap", Namespace: "src"},
+			Status:     &volumesnapshotv1.VolumeSnapshotStatus{ReadyToUse: &notReady},
+		})
+		wh := &dataVolumeValidatingWebhook{snapshotClient: snapshotClient}
+
+		spec := &cdicorev1alpha1.DataVolumeSpec{
+			Source: cdicorev1alpha1.DataVolumeSource{VolumeSnapshot: &cdicorev1alpha1.DataVolumeSourceVolumeSnapshot{Namespace: "src", Name: "snap"}},
+			PVC: &v1.PersistentVolumeClaimSpec{
+				Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")}},
+			},
+		}
+		cause := wh.validateVolumeSnapshotSource(spec, k8sfield.NewPath("spec"))
+		Expect(cause).ToNot(BeNil())
+	})
+
+	It("rejects when the target PVC is smaller than the snapshot restoreSize", func() {
+		ready := true
+		restoreSize := resource.MustParse("5Gi")
+		snapshotClient := snapshotfake.NewSimpleClientset(&volumesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "snap", Namespace: "src"},
+			Status: &volumesnapshotv1.VolumeSnapshotStatus{
+				ReadyToUse:  &ready,
+				RestoreSize: &restoreSize,
+			},
+		})
+		wh := &dataVolumeValidatingWebhook{snapshotClient: snapshotClient}
+
+		spec := &cdicorev1alpha1.DataVolumeSpec{
+			Source: cdicorev1alpha1.DataVolumeSource{VolumeSnapshot: &cdicorev1alpha1.DataVolumeSourceVolumeSnapshot{Namespace: "src", Name: "snap"}},
+			PVC: &v1.PersistentVolumeClaimSpec{
+				Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")}},
+			},
+		}
+		cause := wh.validateVolumeSnapshotSource(spec, k8sfield.NewPath("spec"))
+		Expect(cause).ToNot(BeNil())
+		Expect(cause.Field).To(Equal("spec.PVC.resources.requests.size"))
+	})
+
+	It("allows a ready snapshot that fits the target PVC", func() {
+		ready := true
+		restoreSize := resource.MustParse("1Gi")
+		snapshotClient := snapshotfake.NewSimpleClientset(&volumesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "snap", Namespace: "src"},
+			Status: &volumesnapshotv1.VolumeSnapshotStatus{
+				ReadyToUse:  &ready,
+				RestoreSize: &restoreSize,
+			},
+		})
+		wh := &dataVolumeValidatingWebhook{snapshotClient: snapshotClient}
+
+		spec := &cdicorev1alpha1.DataVolumeSpec{
+			Source: cdicorev1alpha1.DataVolumeSource{VolumeSnapshot: &cdicorev1alpha1.DataVolumeSourceVolumeSnapshot{Namespace: "src", Name: "snap"}},
+			PVC: &v1.PersistentVolumeClaimSpec{
+				Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("2Gi")}},
+			},
+		}
+		cause := wh.validateVolumeSnapshotSource(spec, k8sfield.NewPath("spec"))
+		Expect(cause).To(BeNil())
+	})
+
+	It("rejects a target storage class whose provisioner doesn't match the snapshot's VolumeSnapshotClass driver", func() {
+		ready := true
+		snapshotClassName := "snap-class"
+		snapshotClient := snapshotfake.NewSimpleClientset(
+			&volumesnapshotv1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "snap", Namespace: "src"},
+				Spec:       volumesnapshotv1.VolumeSnapshotSpec{VolumeSnapshotClassName: &snapshotClassName},
+				Status:     &volumesnapshotv1.VolumeSnapshotStatus{ReadyToUse: &ready},
+			},
+			&volumesnapshotv1.VolumeSnapshotClass{
+				ObjectMeta: metav1.ObjectMeta{Name: snapshotClassName},
+				Driver:     "driver.example.com",
+			},
+		)
+		storageClassName := "target-class"
+		client := k8sfake.NewSimpleClientset(&storagev1.StorageClass{
+			ObjectMeta:  metav1.ObjectMeta{Name: storageClassName},
+			Provisioner: "other.example.com",
+		})
+		wh := &dataVolumeValidatingWebhook{client: client, snapshotClient: snapshotClient}
+
+		spec := &cdicorev1alpha1.DataVolumeSpec{
+			Source: cdicorev1alpha1.DataVolumeSource{VolumeSnapshot: &cdicorev1alpha1.DataVolumeSourceVolumeSnapshot{Namespace: "src", Name: "snap"}},
+			PVC: &v1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClassName,
+				Resources:        v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")}},
+			},
+		}
+		cause := wh.validateVolumeSnapshotSource(spec, k8sfield.NewPath("spec"))
+		Expect(cause).ToNot(BeNil())
+		Expect(cause.Field).To(Equal("spec.PVC.storageClassName"))
+	})
+})
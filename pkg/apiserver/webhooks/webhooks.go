@@ -0,0 +1,35 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package webhooks
+
+import (
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/clientset/versioned"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewDataVolumeValidatingWebhook creates a new dataVolumeValidatingWebhook, wired
+// with the clients it needs to look up clone sources (PersistentVolumeClaims,
+// ConfigMaps, SubjectAccessReviews) and VolumeSnapshot clone sources.
+func NewDataVolumeValidatingWebhook(client kubernetes.Interface, snapshotClient snapshotv1.Interface) *dataVolumeValidatingWebhook {
+	return &dataVolumeValidatingWebhook{
+		client:         client,
+		snapshotClient: snapshotClient,
+	}
+}
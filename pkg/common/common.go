@@ -0,0 +1,31 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+// Package common holds constants shared across CDI's components.
+package common
+
+const (
+	// UploadPathSync is the path the upload proxy exposes for single-shot, synchronous uploads
+	UploadPathSync = "/v1beta1/upload"
+
+	// AnnPodPhase is the annotation on a PVC recording the phase of its populator pod
+	AnnPodPhase = "cdi.kubevirt.io/storage.pod.phase"
+	// AnnPodReady is the annotation on a PVC recording whether its populator pod is ready to accept data
+	AnnPodReady = "cdi.kubevirt.io/storage.pod.ready"
+)
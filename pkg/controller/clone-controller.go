@@ -0,0 +1,57 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package controller
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// AnnPopulatedFor is the annotation set on a target PVC once it has been (or is being) populated for a DataVolume
+	AnnPopulatedFor = "cdi.kubevirt.io/storage.populatedFor"
+)
+
+// ValidateCanCloneSourceAndTargetSpec validates the source and target PVC specs are compatible for cloning.
+// The target must request at least as much storage as the source, and either have no access modes of its
+// own or request access modes the source PVC actually supports.
+func ValidateCanCloneSourceAndTargetSpec(sourceSpec, targetSpec *v1.PersistentVolumeClaimSpec) error {
+	sourceRequest := sourceSpec.Resources.Requests[v1.ResourceStorage]
+	targetRequest := targetSpec.Resources.Requests[v1.ResourceStorage]
+	if targetRequest.Cmp(sourceRequest) < 0 {
+		return fmt.Errorf("target resources requests storage size is smaller than the source")
+	}
+
+	if len(targetSpec.AccessModes) == 0 {
+		return nil
+	}
+
+	sourceModes := make(map[v1.PersistentVolumeAccessMode]bool)
+	for _, mode := range sourceSpec.AccessModes {
+		sourceModes[mode] = true
+	}
+	for _, mode := range targetSpec.AccessModes {
+		if !sourceModes[mode] {
+			return fmt.Errorf("source PVC does not support requested access mode %s", mode)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package controller
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	cdicorev1alpha1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+)
+
+// snapshotAPIGroup is the API group of the external-snapshotter VolumeSnapshot CRD,
+// used to populate a PVC's dataSource so the CSI driver restores it directly.
+const snapshotAPIGroup = "snapshot.storage.k8s.io"
+
+// CreatePVCFromSnapshotSource provisions the target PVC for a DataVolume whose source
+// is a VolumeSnapshot. Rather than running a pod-based import/copy, the PVC's
+// dataSource is pointed at the snapshot so the underlying CSI driver restores the
+// volume directly, the same way backup tooling hands a staged VolumeSnapshot off to
+// a downstream restore step. A PVC's dataSource can only name an object in the
+// PVC's own namespace, so a snapshot in any other namespace is rejected with an
+// error. Admission already verified the snapshot is readyToUse and size compatible
+// with dv.Spec.PVC (and storage-class compatible, when both classes are named)
+// before this is called.
+func CreatePVCFromSnapshotSource(client kubernetes.Interface, dv *cdicorev1alpha1.DataVolume) (*v1.PersistentVolumeClaim, error) {
+	snapshotSource := dv.Spec.Source.VolumeSnapshot
+	if snapshotSource.Namespace != dv.Namespace {
+		return nil, fmt.Errorf("VolumeSnapshot %s/%s is not in the DataVolume's namespace %s", snapshotSource.Namespace, snapshotSource.Name, dv.Namespace)
+	}
+
+	pvcSpec := dv.Spec.PVC.DeepCopy()
+	apiGroup := snapshotAPIGroup
+	pvcSpec.DataSource = &v1.TypedLocalObjectReference{
+		APIGroup: &apiGroup,
+		Kind:     "VolumeSnapshot",
+		Name:     snapshotSource.Name,
+	}
+
+	pvc := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dv.Name,
+			Namespace: dv.Namespace,
+			Annotations: map[string]string{
+				AnnPopulatedFor: dv.Name,
+			},
+		},
+		Spec: *pvcSpec,
+	}
+
+	created, err := client.CoreV1().PersistentVolumeClaims(dv.Namespace).Create(pvc)
+	if err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return client.CoreV1().PersistentVolumeClaims(dv.Namespace).Get(dv.Name, metav1.GetOptions{})
+		}
+		return nil, err
+	}
+	return created, nil
+}
@@ -0,0 +1,75 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	cdicorev1alpha1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+)
+
+func newSnapshotSourceDataVolume() *cdicorev1alpha1.DataVolume {
+	return &cdicorev1alpha1.DataVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "target-dv", Namespace: "ns"},
+		Spec: cdicorev1alpha1.DataVolumeSpec{
+			Source: cdicorev1alpha1.DataVolumeSource{
+				VolumeSnapshot: &cdicorev1alpha1.DataVolumeSourceVolumeSnapshot{Namespace: "ns", Name: "snap"},
+			},
+			PVC: &v1.PersistentVolumeClaimSpec{
+				AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			},
+		},
+	}
+}
+
+var _ = Describe("CreatePVCFromSnapshotSource", func() {
+	It("creates a PVC whose dataSource points at the VolumeSnapshot and is marked populated", func() {
+		client := k8sfake.NewSimpleClientset()
+		dv := newSnapshotSourceDataVolume()
+
+		pvc, err := CreatePVCFromSnapshotSource(client, dv)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pvc.Name).To(Equal(dv.Name))
+		Expect(pvc.Namespace).To(Equal(dv.Namespace))
+		Expect(pvc.Annotations[AnnPopulatedFor]).To(Equal(dv.Name))
+		Expect(pvc.Spec.DataSource).ToNot(BeNil())
+		Expect(pvc.Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+		Expect(pvc.Spec.DataSource.Name).To(Equal("snap"))
+		Expect(*pvc.Spec.DataSource.APIGroup).To(Equal(snapshotAPIGroup))
+	})
+
+	It("returns the existing PVC when one already exists", func() {
+		dv := newSnapshotSourceDataVolume()
+		existing := &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: dv.Name, Namespace: dv.Namespace},
+		}
+		client := k8sfake.NewSimpleClientset(existing)
+
+		pvc, err := CreatePVCFromSnapshotSource(client, dv)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pvc.UID).To(Equal(existing.UID))
+		Expect(pvc.Spec.DataSource).To(BeNil())
+	})
+})
@@ -0,0 +1,111 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+// Package token issues and validates the tokens the apiserver hands clients so
+// they can authenticate directly to the upload proxy. Generated tokens carry no
+// expiry claim.
+package token
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dgrijalva/jwt-go"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Operation identifies what the holder of a token is authorized to do
+type Operation string
+
+const (
+	// OperationUpload authorizes uploading data to a PVC
+	OperationUpload Operation = "UploadOperation"
+)
+
+// Payload is the set of claims carried by an upload/clone token
+type Payload struct {
+	Operation Operation                  `json:"operation"`
+	Name      string                     `json:"name"`
+	Namespace string                     `json:"namespace"`
+	Resource  metav1.GroupVersionResource `json:"resource"`
+}
+
+// Validator validates a token string and returns the Payload it carries
+type Validator interface {
+	Validate(string) (*Payload, error)
+}
+
+// Generator generates a signed token string for the given Payload
+type Generator interface {
+	Generate(*Payload) (string, error)
+}
+
+type payloadClaims struct {
+	jwt.StandardClaims
+	Payload string `json:"payload"`
+}
+
+type validator struct {
+	key *rsa.PublicKey
+}
+
+// NewValidator creates a Validator that verifies tokens signed by the private key
+// matching the given public key.
+func NewValidator(key *rsa.PublicKey) Validator {
+	return &validator{key: key}
+}
+
+func (v *validator) Validate(tokenString string) (*Payload, error) {
+	claims := &payloadClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return v.key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &Payload{}
+	if err := json.Unmarshal([]byte(claims.Payload), payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+type generator struct {
+	key *rsa.PrivateKey
+}
+
+// NewGenerator creates a Generator that signs tokens with the given private key.
+func NewGenerator(key *rsa.PrivateKey) Generator {
+	return &generator{key: key}
+}
+
+func (g *generator) Generate(payload *Payload) (string, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, &payloadClaims{Payload: string(encoded)})
+	return token.SignedString(g.key)
+}
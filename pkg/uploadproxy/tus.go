@@ -0,0 +1,63 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package uploadproxy
+
+import (
+	"net/http"
+
+	"k8s.io/klog"
+)
+
+// tusUploadPath is the base path for the tus.io resumable upload endpoint family. A
+// created upload lives at tusUploadPath/<id>, mirroring the tus creation-extension
+// convention.
+const tusUploadPath = "/v1beta1/upload-tus"
+
+const (
+	tusResumableHeader = "Tus-Resumable"
+	tusVersion         = "1.0.0"
+)
+
+// handleTusRequest serves the tus.io resumable upload protocol: POST creates an
+// upload, HEAD reports how much of it has been received, and PATCH appends a chunk.
+// All three are thin, stateless proxies to the upload-server pod owning the target
+// PVC -- the offset/length/checksum bookkeeping lives there, not here, so this proxy
+// can be restarted or horizontally scaled without losing in-flight uploads.
+func (app *uploadProxyApp) handleTusRequest(w http.ResponseWriter, r *http.Request) {
+	payload, status, err := app.authorize(r)
+	if err != nil {
+		klog.Errorf("Rejected tus upload request: %v", err)
+		w.WriteHeader(status)
+		return
+	}
+
+	if !app.populatorPodReady(payload.Namespace, payload.Name) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost, http.MethodHead, http.MethodPatch:
+		w.Header().Set(tusResumableHeader, tusVersion)
+		app.proxyToUploadServer(w, r, payload)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
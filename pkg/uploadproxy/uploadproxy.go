@@ -0,0 +1,195 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+package uploadproxy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/token"
+	"kubevirt.io/containerized-data-importer/pkg/util/cert"
+	"kubevirt.io/containerized-data-importer/pkg/util/cert/fetcher"
+)
+
+const healthzPath = "/healthz"
+
+const authHeaderBearerPrefix = "Bearer "
+
+// urlResolverFunc resolves the upload-server pod URL to proxy a request to, given the
+// namespace, name and resource of the PVC the caller's token authorized them for.
+type urlResolverFunc func(namespace, name, resource string) string
+
+// clientCreatorInterface builds the mTLS http.Client the proxy uses to talk to
+// upload-server pods.
+type clientCreatorInterface interface {
+	CreateClient() (*http.Client, error)
+}
+
+type clientCreator struct {
+	certFetcher   fetcher.CertFetcher
+	bundleFetcher fetcher.CertBundleFetcher
+}
+
+func (cc *clientCreator) CreateClient() (*http.Client, error) {
+	clientCert, err := cc.certFetcher.Certificate()
+	if err != nil {
+		return nil, err
+	}
+	caBundle, err := cc.bundleFetcher.CertBundle()
+	if err != nil {
+		return nil, err
+	}
+	pool, err := cert.CertPoolFromBundle(caBundle)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				Certificates: []tls.Certificate{clientCert},
+				RootCAs:      pool,
+			},
+		},
+	}, nil
+}
+
+type uploadProxyApp struct {
+	client         kubernetes.Interface
+	tokenValidator token.Validator
+	urlResolver    urlResolverFunc
+	clientCreator  clientCreatorInterface
+	handler        http.Handler
+}
+
+func (app *uploadProxyApp) initHandlers() {
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthzPath, app.handleHealthz)
+	mux.HandleFunc(common.UploadPathSync, app.handleSyncUpload)
+	mux.HandleFunc(tusUploadPath, app.handleTusRequest)
+	mux.HandleFunc(tusUploadPath+"/", app.handleTusRequest)
+	app.handler = mux
+}
+
+func (app *uploadProxyApp) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	app.handler.ServeHTTP(w, r)
+}
+
+func (app *uploadProxyApp) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// getSigningKey configures the token validator the proxy uses to authenticate every
+// incoming upload request against the public key of the apiserver-issued upload token.
+func (app *uploadProxyApp) getSigningKey(publicKeyPEM string) error {
+	key, err := cert.DecodePublicKeyPEM(publicKeyPEM)
+	if err != nil {
+		return err
+	}
+	app.tokenValidator = token.NewValidator(key)
+	return nil
+}
+
+// authorize validates the bearer token on the request and returns the payload
+// describing which PVC the caller is allowed to upload to.
+func (app *uploadProxyApp) authorize(r *http.Request) (*token.Payload, int, error) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, authHeaderBearerPrefix) {
+		return nil, http.StatusBadRequest, fmt.Errorf("missing or malformed Authorization header")
+	}
+
+	payload, err := app.tokenValidator.Validate(strings.TrimPrefix(header, authHeaderBearerPrefix))
+	if err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+	return payload, http.StatusOK, nil
+}
+
+// handleSyncUpload proxies the single-shot upload endpoint straight through to the
+// upload-server pod backing the target PVC.
+func (app *uploadProxyApp) handleSyncUpload(w http.ResponseWriter, r *http.Request) {
+	payload, status, err := app.authorize(r)
+	if err != nil {
+		klog.Errorf("Rejected upload request: %v", err)
+		w.WriteHeader(status)
+		return
+	}
+	if !app.populatorPodReady(payload.Namespace, payload.Name) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	app.proxyToUploadServer(w, r, payload)
+}
+
+// populatorPodReady reports whether the upload-server pod backing the target PVC has
+// reported itself running and ready to accept a connection.
+func (app *uploadProxyApp) populatorPodReady(namespace, name string) bool {
+	pvc, err := app.client.CoreV1().PersistentVolumeClaims(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	return pvc.Annotations[common.AnnPodPhase] == string(v1.PodRunning) && pvc.Annotations[common.AnnPodReady] == "true"
+}
+
+// proxyToUploadServer streams the request through to the upload-server pod owning
+// the PVC named in payload, and streams the response back verbatim, so neither the
+// request body (a disk image chunk) nor the response needs to be buffered in full.
+func (app *uploadProxyApp) proxyToUploadServer(w http.ResponseWriter, r *http.Request, payload *token.Payload) {
+	targetURL := app.urlResolver(payload.Namespace, payload.Name, payload.Resource.Resource)
+
+	client, err := app.clientCreator.CreateClient()
+	if err != nil {
+		klog.Errorf("Error creating upload-server client: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	proxyReq, err := http.NewRequest(r.Method, targetURL+r.URL.Path, r.Body)
+	if err != nil {
+		klog.Errorf("Error constructing upload-server request: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	proxyReq.Header = r.Header.Clone()
+
+	resp, err := client.Do(proxyReq)
+	if err != nil {
+		klog.Errorf("Error proxying to upload-server: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer resp.Body.Close()
+
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
@@ -0,0 +1,55 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2019 Red Hat, Inc.
+ *
+ */
+
+// Package fetcher abstracts over where a component's TLS certificate and trust
+// bundle come from (a mounted secret in production, an in-memory value in tests).
+package fetcher
+
+import "crypto/tls"
+
+// CertFetcher returns the client certificate/key pair to present
+type CertFetcher interface {
+	Certificate() (tls.Certificate, error)
+}
+
+// CertBundleFetcher returns a PEM-encoded CA bundle to trust
+type CertBundleFetcher interface {
+	CertBundle() ([]byte, error)
+}
+
+// MemCertFetcher is a CertFetcher backed by in-memory PEM bytes, used in tests
+type MemCertFetcher struct {
+	Cert []byte
+	Key  []byte
+}
+
+// Certificate implements CertFetcher
+func (f *MemCertFetcher) Certificate() (tls.Certificate, error) {
+	return tls.X509KeyPair(f.Cert, f.Key)
+}
+
+// MemCertBundleFetcher is a CertBundleFetcher backed by an in-memory PEM bundle, used in tests
+type MemCertBundleFetcher struct {
+	Bundle []byte
+}
+
+// CertBundle implements CertBundleFetcher
+func (f *MemCertBundleFetcher) CertBundle() ([]byte, error) {
+	return f.Bundle, nil
+}